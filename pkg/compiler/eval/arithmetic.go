@@ -0,0 +1,80 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package eval
+
+import (
+	"github.com/marapongo/mu/pkg/compiler/types"
+	"github.com/marapongo/mu/pkg/util/contract"
+)
+
+// isNumeric returns true if the object is either an Integer or a Number.
+func isNumeric(o *Object) bool {
+	return o.Type() == types.Integer || o.Type() == types.Number
+}
+
+// asFloat64 coerces a numeric object to a float64, regardless of whether it is an Integer or Number underneath.
+func asFloat64(o *Object) float64 {
+	if o.Type() == types.Integer {
+		return float64(o.IntValue())
+	}
+	return o.NumberValue()
+}
+
+// Add adds two numeric objects together, preserving integer-ness if both operands are Integers.
+func Add(lhs *Object, rhs *Object) *Object {
+	contract.Assertf(isNumeric(lhs) && isNumeric(rhs), "Expected numeric operands to Add")
+	if lhs.Type() == types.Integer && rhs.Type() == types.Integer {
+		return NewIntObject(lhs.IntValue() + rhs.IntValue())
+	}
+	return NewObject(types.Number, asFloat64(lhs)+asFloat64(rhs), nil)
+}
+
+// Sub subtracts rhs from lhs, preserving integer-ness if both operands are Integers.
+func Sub(lhs *Object, rhs *Object) *Object {
+	contract.Assertf(isNumeric(lhs) && isNumeric(rhs), "Expected numeric operands to Sub")
+	if lhs.Type() == types.Integer && rhs.Type() == types.Integer {
+		return NewIntObject(lhs.IntValue() - rhs.IntValue())
+	}
+	return NewObject(types.Number, asFloat64(lhs)-asFloat64(rhs), nil)
+}
+
+// Mul multiplies two numeric objects, preserving integer-ness if both operands are Integers.
+func Mul(lhs *Object, rhs *Object) *Object {
+	contract.Assertf(isNumeric(lhs) && isNumeric(rhs), "Expected numeric operands to Mul")
+	if lhs.Type() == types.Integer && rhs.Type() == types.Integer {
+		return NewIntObject(lhs.IntValue() * rhs.IntValue())
+	}
+	return NewObject(types.Number, asFloat64(lhs)*asFloat64(rhs), nil)
+}
+
+// Div divides lhs by rhs.  If both operands are Integers and the division is exact, the result is an Integer;
+// otherwise, the result is promoted to a Number.
+func Div(lhs *Object, rhs *Object) *Object {
+	contract.Assertf(isNumeric(lhs) && isNumeric(rhs), "Expected numeric operands to Div")
+	if lhs.Type() == types.Integer && rhs.Type() == types.Integer {
+		l, r := lhs.IntValue(), rhs.IntValue()
+		contract.Assertf(r != 0, "Unexpected division by zero")
+		if l%r == 0 {
+			return NewIntObject(l / r)
+		}
+		return NewObject(types.Number, float64(l)/float64(r), nil)
+	}
+	return NewObject(types.Number, asFloat64(lhs)/asFloat64(rhs), nil)
+}
+
+// Mod computes lhs modulo rhs; both operands must be Integers, since modulo is undefined over floating point Numbers.
+func Mod(lhs *Object, rhs *Object) *Object {
+	contract.Assertf(lhs.Type() == types.Integer && rhs.Type() == types.Integer, "Expected Integer operands to Mod")
+	r := rhs.IntValue()
+	contract.Assertf(r != 0, "Unexpected modulo by zero")
+	return NewIntObject(lhs.IntValue() % r)
+}
+
+// NumEquals compares two numeric objects for equality, coercing Integers to Numbers as needed for mixed comparisons.
+func NumEquals(lhs *Object, rhs *Object) bool {
+	contract.Assertf(isNumeric(lhs) && isNumeric(rhs), "Expected numeric operands to NumEquals")
+	if lhs.Type() == types.Integer && rhs.Type() == types.Integer {
+		return lhs.IntValue() == rhs.IntValue()
+	}
+	return asFloat64(lhs) == asFloat64(rhs)
+}
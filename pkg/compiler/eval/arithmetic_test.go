@@ -0,0 +1,57 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package eval
+
+import (
+	"testing"
+
+	"github.com/marapongo/mu/pkg/compiler/types"
+)
+
+func TestDivPreservesIntegerOnExactDivision(t *testing.T) {
+	result := Div(NewIntObject(6), NewIntObject(3))
+	if result.Type() != types.Integer {
+		t.Fatalf("Expected exact Integer division to stay an Integer; got %v", result.Type())
+	}
+	if result.IntValue() != 2 {
+		t.Fatalf("Expected 6/3 == 2; got %v", result.IntValue())
+	}
+}
+
+func TestDivPromotesToNumberOnInexactDivision(t *testing.T) {
+	result := Div(NewIntObject(7), NewIntObject(2))
+	if result.Type() != types.Number {
+		t.Fatalf("Expected inexact Integer division to promote to Number; got %v", result.Type())
+	}
+	if result.NumberValue() != 3.5 {
+		t.Fatalf("Expected 7/2 == 3.5; got %v", result.NumberValue())
+	}
+}
+
+func TestDivByZeroPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected division by zero to panic")
+		}
+	}()
+	Div(NewIntObject(1), NewIntObject(0))
+}
+
+func TestModByZeroPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected modulo by zero to panic")
+		}
+	}()
+	Mod(NewIntObject(1), NewIntObject(0))
+}
+
+func TestAddPromotesToNumberOnMixedOperands(t *testing.T) {
+	result := Add(NewIntObject(1), NewObject(types.Number, float64(1.5), nil))
+	if result.Type() != types.Number {
+		t.Fatalf("Expected mixed-type Add to promote to Number; got %v", result.Type())
+	}
+	if result.NumberValue() != 2.5 {
+		t.Fatalf("Expected 1+1.5 == 2.5; got %v", result.NumberValue())
+	}
+}
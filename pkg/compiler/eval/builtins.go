@@ -0,0 +1,39 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package eval
+
+import (
+	"github.com/marapongo/mu/pkg/compiler/symbols"
+	"github.com/marapongo/mu/pkg/compiler/types"
+	"github.com/marapongo/mu/pkg/tokens"
+	"github.com/marapongo/mu/pkg/util/contract"
+)
+
+// Builtins is the table of built-in, free (non-method) functions available to every MuIL program, keyed by their
+// unqualified name.  The evaluator consults this table -- after failing to resolve a call target against the
+// symbol table -- so that calls like `len(x)` dispatch here rather than requiring a declared function.
+var Builtins = map[tokens.Name]func([]*Object) *Object{
+	"len": func(args []*Object) *Object {
+		contract.Assertf(len(args) == 1, "Expected exactly one argument to len(); got %v", len(args))
+		return Len(args[0])
+	},
+}
+
+// Len implements the `len` builtin, returning the number of elements in an array or map, or the number of
+// bytes in a string.  Any other object type is a contract violation, since the type checker should have
+// caught it earlier.
+func Len(obj *Object) *Object {
+	switch obj.Type() {
+	case types.String:
+		return NewObject(types.Number, float64(len(obj.StringValue())), nil)
+	default:
+		if _, isarr := obj.Type().(*symbols.ArrayType); isarr {
+			return NewObject(types.Number, float64(len(obj.ArrayValue())), nil)
+		}
+		if _, ismap := obj.Type().(*symbols.MapType); ismap {
+			return NewObject(types.Number, float64(len(obj.MapValue())), nil)
+		}
+		contract.Failf("Expected array, map, or string object for len(); got %v", obj.Type())
+		return nil
+	}
+}
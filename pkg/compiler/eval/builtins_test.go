@@ -0,0 +1,46 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package eval
+
+import (
+	"testing"
+
+	"github.com/marapongo/mu/pkg/compiler/types"
+)
+
+func TestGetElementAddrGrowsArray(t *testing.T) {
+	arr := NewArrayObject(types.Number, nil)
+	*arr.GetElementAddr(2, true) = Pointer{Obj: NewObject(types.Number, float64(9), nil)}
+	if got := len(arr.ArrayValue()); got != 3 {
+		t.Fatalf("Expected array to grow to length 3; got %v", got)
+	}
+	if got := arr.GetElementAddr(2, false).String(); got != "9" {
+		t.Fatalf("Expected element 2 to be 9; got %v", got)
+	}
+}
+
+func TestGetElementAddrOutOfRangeWithoutInitPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected out-of-range GetElementAddr without init to panic")
+		}
+	}()
+	arr := NewArrayObject(types.Number, nil)
+	arr.GetElementAddr(0, false)
+}
+
+func TestLen(t *testing.T) {
+	arr := NewArrayObject(types.Number, []*Pointer{{}, {}, {}})
+	if got := Len(arr).NumberValue(); got != 3 {
+		t.Fatalf("Expected len(array) == 3; got %v", got)
+	}
+
+	s := NewObject(types.String, "hello", nil)
+	if got := Len(s).NumberValue(); got != 5 {
+		t.Fatalf("Expected len(string) == 5; got %v", got)
+	}
+
+	if got := Builtins["len"]([]*Object{s}).NumberValue(); got != 5 {
+		t.Fatalf("Expected Builtins[\"len\"] to dispatch to Len; got %v", got)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package eval
+
+import (
+	"github.com/marapongo/mu/pkg/compiler/symbols"
+	"github.com/marapongo/mu/pkg/tokens"
+)
+
+// Context carries the ambient, per-evaluation state needed to resolve tokens against a live symbol table -- for
+// example, when reconstructing an object graph previously externalized by the snapshot package.
+type Context struct {
+	types     map[tokens.Type]symbols.Type
+	functions map[tokens.Token]symbols.Function
+}
+
+// NewContext allocates a new, empty Context.
+func NewContext() *Context {
+	return &Context{types: make(map[tokens.Type]symbols.Type), functions: make(map[tokens.Token]symbols.Function)}
+}
+
+// RegisterType makes t resolvable by token through LookupType.
+func (ctx *Context) RegisterType(t symbols.Type) {
+	ctx.types[tokens.Type(t.Token().String())] = t
+}
+
+// RegisterFunction makes fnc resolvable by token through LookupFunction.
+func (ctx *Context) RegisterFunction(fnc symbols.Function) {
+	ctx.functions[tokens.Token(fnc.Token().String())] = fnc
+}
+
+// LookupType resolves a type token against the context's symbol table.
+func (ctx *Context) LookupType(tok tokens.Type) (symbols.Type, bool) {
+	t, ok := ctx.types[tok]
+	return t, ok
+}
+
+// LookupFunction resolves a function token against the context's symbol table.
+func (ctx *Context) LookupFunction(tok tokens.Token) (symbols.Function, bool) {
+	fnc, ok := ctx.functions[tok]
+	return fnc, ok
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package eval
+
+import (
+	"github.com/marapongo/mu/pkg/compiler/symbols"
+	"github.com/marapongo/mu/pkg/compiler/types"
+)
+
+// NewDefaultObject allocates the zero value for a given type, used to initialize variables and struct properties
+// that lack an explicit initializer.  Zero-valued hashes, for instance, are well-defined and compare equal to one
+// another, per Equals; zero-valued arrays and maps are empty, not nil, so that ArrayValue/MapValue may be used
+// immediately without a prior initialization check.
+func NewDefaultObject(t symbols.Type) *Object {
+	switch u := t.(type) {
+	case *symbols.ArrayType:
+		return NewArrayObject(u.Element, nil)
+	case *symbols.MapType:
+		return NewMapObject(u.Key, u.Value)
+	}
+
+	switch t {
+	case types.Bool:
+		return NewObject(types.Bool, false, nil)
+	case types.Number:
+		return NewObject(types.Number, float64(0), nil)
+	case types.Integer:
+		return NewIntObject(0)
+	case types.String:
+		return NewObject(types.String, "", nil)
+	case types.Bytes:
+		return NewBytesObject(nil)
+	case types.Hash160:
+		return NewHash160Object([20]byte{})
+	case types.Hash256:
+		return NewHash256Object([32]byte{})
+	case types.PublicKey:
+		return NewPublicKeyObject(nil)
+	case types.Signature:
+		return NewSignatureObject(nil)
+	case types.Null:
+		return NewObject(types.Null, nil, nil)
+	default:
+		return NewObject(t, nil, make(Properties))
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package eval
+
+import (
+	"testing"
+
+	"github.com/marapongo/mu/pkg/compiler/symbols"
+	"github.com/marapongo/mu/pkg/compiler/types"
+)
+
+func TestNewDefaultObjectArrayIsUsable(t *testing.T) {
+	arr := NewDefaultObject(symbols.NewArrayType(types.Number))
+	if got := len(arr.ArrayValue()); got != 0 {
+		t.Fatalf("Expected default Array to be empty; got length %v", got)
+	}
+}
+
+func TestNewDefaultObjectMapIsUsable(t *testing.T) {
+	m := NewDefaultObject(symbols.NewMapType(types.String, types.Number))
+	if got := len(m.MapValue()); got != 0 {
+		t.Fatalf("Expected default Map to be empty; got length %v", got)
+	}
+}
+
+func TestNewPublicKeyObjectRejectsWrongLength(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected NewPublicKeyObject to reject a key of the wrong length")
+		}
+	}()
+	NewPublicKeyObject(make([]byte, PublicKeyLength-1))
+}
+
+func TestNewSignatureObjectRejectsWrongLength(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected NewSignatureObject to reject a signature of the wrong length")
+		}
+	}()
+	NewSignatureObject(make([]byte, SignatureLength+1))
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package eval
+
+import (
+	"bytes"
+
+	"github.com/marapongo/mu/pkg/compiler/types"
+	"github.com/marapongo/mu/pkg/util/contract"
+)
+
+// Equals compares two objects for equality.  Numeric objects are compared using NumEquals so that Integers and
+// Numbers may be compared across types; Bytes, PublicKey, and Signature objects -- being variable-length byte
+// slices -- are compared by content rather than by identity, so that, in particular, two zero-valued instances of
+// these types always compare equal.  Hash160 and Hash256 are fixed-size byte arrays and so compare equal natively.
+// Null has a single logical value, so any two Null objects are always equal, regardless of how they were
+// constructed.
+func Equals(lhs *Object, rhs *Object) bool {
+	if isNumeric(lhs) && isNumeric(rhs) {
+		return NumEquals(lhs, rhs)
+	}
+
+	contract.Assertf(lhs.Type() == rhs.Type(), "Expected operands of the same type to Equals; got %v and %v",
+		lhs.Type(), rhs.Type())
+
+	switch lhs.Type() {
+	case types.Bytes:
+		return bytes.Equal(lhs.BytesValue(), rhs.BytesValue())
+	case types.PublicKey:
+		return bytes.Equal(lhs.PublicKeyValue(), rhs.PublicKeyValue())
+	case types.Signature:
+		return bytes.Equal(lhs.SignatureValue(), rhs.SignatureValue())
+	case types.Hash160:
+		return lhs.Hash160Value() == rhs.Hash160Value()
+	case types.Hash256:
+		return lhs.Hash256Value() == rhs.Hash256Value()
+	case types.Null:
+		return true
+	case types.Bool:
+		return lhs.BoolValue() == rhs.BoolValue()
+	case types.String:
+		return lhs.StringValue() == rhs.StringValue()
+	default:
+		return lhs == rhs
+	}
+}
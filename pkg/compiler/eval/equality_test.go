@@ -0,0 +1,93 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package eval
+
+import (
+	"testing"
+
+	"github.com/marapongo/mu/pkg/compiler/types"
+)
+
+func TestEqualsBytes(t *testing.T) {
+	if !Equals(NewBytesObject([]byte{1, 2, 3}), NewBytesObject([]byte{1, 2, 3})) {
+		t.Fatalf("expected equal Bytes objects with identical content to compare equal")
+	}
+	if Equals(NewBytesObject([]byte{1, 2, 3}), NewBytesObject([]byte{1, 2, 4})) {
+		t.Fatalf("expected Bytes objects with differing content to compare unequal")
+	}
+	if !Equals(NewDefaultObject(types.Bytes), NewDefaultObject(types.Bytes)) {
+		t.Fatalf("expected two zero-valued Bytes objects to compare equal")
+	}
+}
+
+func TestEqualsHash160(t *testing.T) {
+	if !Equals(NewHash160Object([20]byte{1}), NewHash160Object([20]byte{1})) {
+		t.Fatalf("expected equal Hash160 objects to compare equal")
+	}
+	if Equals(NewHash160Object([20]byte{1}), NewHash160Object([20]byte{2})) {
+		t.Fatalf("expected differing Hash160 objects to compare unequal")
+	}
+	if !Equals(NewDefaultObject(types.Hash160), NewDefaultObject(types.Hash160)) {
+		t.Fatalf("expected two zero-valued Hash160 objects to compare equal")
+	}
+}
+
+func TestEqualsHash256(t *testing.T) {
+	if !Equals(NewHash256Object([32]byte{1}), NewHash256Object([32]byte{1})) {
+		t.Fatalf("expected equal Hash256 objects to compare equal")
+	}
+	if Equals(NewHash256Object([32]byte{1}), NewHash256Object([32]byte{2})) {
+		t.Fatalf("expected differing Hash256 objects to compare unequal")
+	}
+	if !Equals(NewDefaultObject(types.Hash256), NewDefaultObject(types.Hash256)) {
+		t.Fatalf("expected two zero-valued Hash256 objects to compare equal")
+	}
+}
+
+func TestEqualsPublicKey(t *testing.T) {
+	k1 := make([]byte, PublicKeyLength)
+	k2 := make([]byte, PublicKeyLength)
+	k2[0] = 1
+	if !Equals(NewPublicKeyObject(k1), NewPublicKeyObject(append([]byte(nil), k1...))) {
+		t.Fatalf("expected equal PublicKey objects to compare equal")
+	}
+	if Equals(NewPublicKeyObject(k1), NewPublicKeyObject(k2)) {
+		t.Fatalf("expected differing PublicKey objects to compare unequal")
+	}
+	if !Equals(NewDefaultObject(types.PublicKey), NewDefaultObject(types.PublicKey)) {
+		t.Fatalf("expected two zero-valued PublicKey objects to compare equal")
+	}
+}
+
+func TestEqualsSignature(t *testing.T) {
+	s1 := make([]byte, SignatureLength)
+	s2 := make([]byte, SignatureLength)
+	s2[0] = 1
+	if !Equals(NewSignatureObject(s1), NewSignatureObject(append([]byte(nil), s1...))) {
+		t.Fatalf("expected equal Signature objects to compare equal")
+	}
+	if Equals(NewSignatureObject(s1), NewSignatureObject(s2)) {
+		t.Fatalf("expected differing Signature objects to compare unequal")
+	}
+	if !Equals(NewDefaultObject(types.Signature), NewDefaultObject(types.Signature)) {
+		t.Fatalf("expected two zero-valued Signature objects to compare equal")
+	}
+}
+
+func TestEqualsNull(t *testing.T) {
+	if !Equals(NewDefaultObject(types.Null), NewDefaultObject(types.Null)) {
+		t.Fatalf("expected two independently-constructed Null objects to compare equal")
+	}
+	if !Equals(NewObject(types.Null, nil, nil), NewObject(types.Null, nil, nil)) {
+		t.Fatalf("expected two Null objects to compare equal regardless of identity")
+	}
+}
+
+func TestEqualsMixedIntegerNumber(t *testing.T) {
+	if !Equals(NewIntObject(42), NewObject(types.Number, float64(42), nil)) {
+		t.Fatalf("expected an Integer and a Number with the same numeric value to compare equal")
+	}
+	if Equals(NewIntObject(42), NewObject(types.Number, float64(42.5), nil)) {
+		t.Fatalf("expected an Integer and a Number with differing numeric values to compare unequal")
+	}
+}
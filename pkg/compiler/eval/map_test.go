@@ -0,0 +1,45 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package eval
+
+import (
+	"testing"
+
+	"github.com/marapongo/mu/pkg/compiler/symbols"
+	"github.com/marapongo/mu/pkg/compiler/types"
+)
+
+func TestNewMapObjectRejectsNonComparableKeyType(t *testing.T) {
+	bad := []symbols.Type{
+		types.Bytes,
+		types.PublicKey,
+		types.Signature,
+		symbols.NewArrayType(types.Number),
+		symbols.NewMapType(types.String, types.Number),
+	}
+	for _, keyType := range bad {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Expected NewMapObject to reject key type %v", keyType)
+				}
+			}()
+			NewMapObject(keyType, types.String)
+		}()
+	}
+}
+
+func TestGetIndexAddrDispatchesToArrayAndMap(t *testing.T) {
+	arr := NewArrayObject(types.Number, []*Pointer{{}, {}})
+	*arr.GetIndexAddr(NewIntObject(1), true) = Pointer{Obj: NewObject(types.Number, float64(42), nil)}
+	if got := arr.GetIndexAddr(NewIntObject(1), false).String(); got != "42" {
+		t.Fatalf("Expected array index to round-trip through GetIndexAddr; got %v", got)
+	}
+
+	m := NewMapObject(types.String, types.String)
+	key := NewObject(types.String, "k", nil)
+	*m.GetIndexAddr(key, true) = Pointer{Obj: NewObject(types.String, "v", nil)}
+	if got := m.GetEntryAddr(key, false).String(); got != "\"v\"" {
+		t.Fatalf("Expected map entry to round-trip through GetIndexAddr; got %v", got)
+	}
+}
@@ -3,7 +3,10 @@
 package eval
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/marapongo/mu/pkg/compiler/symbols"
@@ -30,6 +33,93 @@ func NewObject(t symbols.Type, value Value, properties Properties) *Object {
 	return &Object{t: t, value: value, properties: properties}
 }
 
+// NewArrayObject allocates a new array object with the given element type and elements.
+func NewArrayObject(elemType symbols.Type, elems []*Pointer) *Object {
+	return &Object{t: symbols.NewArrayType(elemType), value: elems}
+}
+
+// NewIntObject allocates a new integer object with the given value.
+func NewIntObject(i int64) *Object {
+	return &Object{t: types.Integer, value: i}
+}
+
+// NewFunctionObject allocates a new function object bound to the given function symbol and, if it is an instance
+// method, the object it is bound to.
+func NewFunctionObject(fnc symbols.Function, this *Object) *Object {
+	return &Object{t: fnc.FuncType(), value: funcStub{This: this, Func: fnc}}
+}
+
+// PublicKeyLength and SignatureLength are the fixed byte lengths enforced on PublicKey and Signature objects,
+// respectively, matching the sizes produced by the project's Ed25519-based identity scheme.
+const (
+	PublicKeyLength = 32
+	SignatureLength = 64
+)
+
+// NewBytesObject allocates a new object carrying a raw, variable-length byte blob.  Unlike PublicKey and Signature,
+// Bytes has no fixed-length invariant to enforce; any length, including zero, is valid.
+func NewBytesObject(b []byte) *Object {
+	return &Object{t: types.Bytes, value: b}
+}
+
+// NewHash160Object allocates a new object carrying a fixed-width, 20-byte hash (e.g., an account hash).
+func NewHash160Object(h [20]byte) *Object {
+	return &Object{t: types.Hash160, value: h}
+}
+
+// NewHash256Object allocates a new object carrying a fixed-width, 32-byte hash.
+func NewHash256Object(h [32]byte) *Object {
+	return &Object{t: types.Hash256, value: h}
+}
+
+// NewPublicKeyObject allocates a new object carrying a public key.  k must either be empty (the zero value) or
+// exactly PublicKeyLength bytes long.
+func NewPublicKeyObject(k []byte) *Object {
+	contract.Assertf(len(k) == 0 || len(k) == PublicKeyLength,
+		"Expected a PublicKey of length 0 or %v; got %v", PublicKeyLength, len(k))
+	return &Object{t: types.PublicKey, value: k}
+}
+
+// NewSignatureObject allocates a new object carrying a cryptographic signature.  s must either be empty (the zero
+// value) or exactly SignatureLength bytes long.
+func NewSignatureObject(s []byte) *Object {
+	contract.Assertf(len(s) == 0 || len(s) == SignatureLength,
+		"Expected a Signature of length 0 or %v; got %v", SignatureLength, len(s))
+	return &Object{t: types.Signature, value: s}
+}
+
+// NewPointerObject allocates a new object that is itself a pointer/reference to another object.
+func NewPointerObject(target *Pointer) *Object {
+	return &Object{t: symbols.NewPointerType(nil), value: target}
+}
+
+// Entries is a map's runtime storage, keyed by the underlying Go value of the key object.
+type Entries map[interface{}]*Pointer
+
+// NewMapObject allocates a new map object with the given key and value element types.  keyType must be comparable
+// -- i.e., its values must be valid Go map keys -- since entries are keyed on the Go value underlying each key
+// object; Bytes, PublicKey, and Signature are backed by []byte and so are rejected.
+func NewMapObject(keyType symbols.Type, valType symbols.Type) *Object {
+	contract.Assertf(isComparableKeyType(keyType), "Expected a comparable Map key type; got %v", keyType)
+	return &Object{t: symbols.NewMapType(keyType, valType), value: make(Entries)}
+}
+
+// isComparableKeyType returns true if t's values are valid Go map keys.  Array and Map key types are rejected
+// structurally, since they are backed by Go slices and maps respectively, neither of which is hashable; Bytes,
+// PublicKey, and Signature are rejected by name for the same reason, since they are backed by []byte.
+func isComparableKeyType(t symbols.Type) bool {
+	switch t.(type) {
+	case *symbols.ArrayType, *symbols.MapType:
+		return false
+	}
+	switch t {
+	case types.Bytes, types.PublicKey, types.Signature:
+		return false
+	default:
+		return true
+	}
+}
+
 func (o *Object) Type() symbols.Type     { return o.t }
 func (o *Object) Value() Value           { return o.value }
 func (o *Object) Properties() Properties { return o.properties }
@@ -52,6 +142,15 @@ func (o *Object) NumberValue() float64 {
 	return n
 }
 
+// IntValue asserts that the target is an integer literal and returns its value.
+func (o *Object) IntValue() int64 {
+	contract.Assertf(o.t == types.Integer, "Expected object type to be Integer; got %v", o.t)
+	contract.Assertf(o.value != nil, "Expected Integer object to carry a Value; got nil")
+	i, ok := o.value.(int64)
+	contract.Assertf(ok, "Expected Integer object's Value to be an integer literal")
+	return i
+}
+
 // StringValue asserts that the target is a string and returns its value.
 func (o *Object) StringValue() string {
 	contract.Assertf(o.t == types.String, "Expected object type to be String; got %v", o.t)
@@ -61,6 +160,65 @@ func (o *Object) StringValue() string {
 	return s
 }
 
+// ArrayValue asserts that the target is an array and returns its value.
+func (o *Object) ArrayValue() []*Pointer {
+	contract.Assertf(o.value != nil, "Expected Array object to carry a Value; got nil")
+	arr, ok := o.value.([]*Pointer)
+	contract.Assertf(ok, "Expected Array object's Value to be a slice of Pointers")
+	return arr
+}
+
+// MapValue asserts that the target is a map and returns its value.
+func (o *Object) MapValue() Entries {
+	contract.Assertf(o.value != nil, "Expected Map object to carry a Value; got nil")
+	e, ok := o.value.(Entries)
+	contract.Assertf(ok, "Expected Map object's Value to be Entries")
+	return e
+}
+
+// BytesValue asserts that the target is a raw byte blob and returns its value.
+func (o *Object) BytesValue() []byte {
+	contract.Assertf(o.t == types.Bytes, "Expected object type to be Bytes; got %v", o.t)
+	contract.Assertf(o.value != nil, "Expected Bytes object to carry a Value; got nil")
+	b, ok := o.value.([]byte)
+	contract.Assertf(ok, "Expected Bytes object's Value to be a byte slice")
+	return b
+}
+
+// Hash160Value asserts that the target is a 160-bit hash and returns its value.
+func (o *Object) Hash160Value() [20]byte {
+	contract.Assertf(o.t == types.Hash160, "Expected object type to be Hash160; got %v", o.t)
+	h, ok := o.value.([20]byte)
+	contract.Assertf(ok, "Expected Hash160 object's Value to be a [20]byte")
+	return h
+}
+
+// Hash256Value asserts that the target is a 256-bit hash and returns its value.
+func (o *Object) Hash256Value() [32]byte {
+	contract.Assertf(o.t == types.Hash256, "Expected object type to be Hash256; got %v", o.t)
+	h, ok := o.value.([32]byte)
+	contract.Assertf(ok, "Expected Hash256 object's Value to be a [32]byte")
+	return h
+}
+
+// PublicKeyValue asserts that the target is a public key and returns its value.
+func (o *Object) PublicKeyValue() []byte {
+	contract.Assertf(o.t == types.PublicKey, "Expected object type to be PublicKey; got %v", o.t)
+	contract.Assertf(o.value != nil, "Expected PublicKey object to carry a Value; got nil")
+	k, ok := o.value.([]byte)
+	contract.Assertf(ok, "Expected PublicKey object's Value to be a byte slice")
+	return k
+}
+
+// SignatureValue asserts that the target is a signature and returns its value.
+func (o *Object) SignatureValue() []byte {
+	contract.Assertf(o.t == types.Signature, "Expected object type to be Signature; got %v", o.t)
+	contract.Assertf(o.value != nil, "Expected Signature object to carry a Value; got nil")
+	s, ok := o.value.([]byte)
+	contract.Assertf(ok, "Expected Signature object's Value to be a byte slice")
+	return s
+}
+
 // FunctionValue asserts that the target is a reference and returns its value.
 func (o *Object) FunctionValue() funcStub {
 	contract.Assertf(o.value != nil, "Expected Function object to carry a Value; got nil")
@@ -88,6 +246,65 @@ func (o *Object) GetPropertyAddr(nm tokens.Name, init bool) *Pointer {
 	return ref
 }
 
+// GetElementAddr returns the reference to an array's i'th element.  If i is out of range and 'init' is true, the
+// underlying array is grown to accommodate the requested index; if 'init' is false, an out-of-range index is a
+// contract violation.
+func (o *Object) GetElementAddr(i int, init bool) *Pointer {
+	contract.Assertf(i >= 0, "Expected a non-negative array index; got %v", i)
+	arr := o.ArrayValue()
+	if i >= len(arr) {
+		contract.Assertf(init, "Array index %v out of range (length %v)", i, len(arr))
+		grown := make([]*Pointer, i+1)
+		copy(grown, arr)
+		for j := len(arr); j <= i; j++ {
+			grown[j] = &Pointer{}
+		}
+		arr = grown
+		o.value = arr
+	}
+	return arr[i]
+}
+
+// GetEntryAddr returns the reference to a map's entry for the given key, lazily initializing it if 'init' is true;
+// if 'init' is false, a missing key is a contract violation.  The key's runtime type is asserted against the map's
+// declared key type.
+func (o *Object) GetEntryAddr(key *Object, init bool) *Pointer {
+	mapType, ismap := o.t.(*symbols.MapType)
+	contract.Assertf(ismap, "Expected a Map object to GetEntryAddr")
+	contract.Assertf(key.Type() == mapType.Key, "Expected map key of type %v; got %v", mapType.Key, key.Type())
+
+	entries := o.MapValue()
+	k := key.Value()
+	ref, has := entries[k]
+	if !has {
+		contract.Assertf(init, "Map key %v not found", k)
+		ref = &Pointer{}
+		entries[k] = ref
+	}
+	return ref
+}
+
+// GetIndexAddr dispatches an indexed-access expression (`receiver[key]`) to either GetElementAddr or GetEntryAddr,
+// based on the receiver's runtime type, so that a single indexed-access AST node can address both arrays and maps.
+func (o *Object) GetIndexAddr(key *Object, init bool) *Pointer {
+	switch o.t.(type) {
+	case *symbols.ArrayType:
+		contract.Assertf(key.Type() == types.Integer || key.Type() == types.Number, "Expected numeric array index")
+		var i int
+		if key.Type() == types.Integer {
+			i = int(key.IntValue())
+		} else {
+			i = int(key.NumberValue())
+		}
+		return o.GetElementAddr(i, init)
+	case *symbols.MapType:
+		return o.GetEntryAddr(key, init)
+	default:
+		contract.Failf("Expected an Array or Map object for indexed access; got %v", o.t)
+		return nil
+	}
+}
+
 // String can be used to print the contents of an object; it tries to be smart about the display.
 func (o *Object) String() string {
 	switch o.t {
@@ -99,10 +316,23 @@ func (o *Object) String() string {
 	case types.String:
 		return "\"" + o.StringValue() + "\""
 	case types.Number:
-		// TODO: it'd be nice to format as ints if the decimal part is close enough to "nothing".
 		return strconv.FormatFloat(o.NumberValue(), 'f', -1, 64)
+	case types.Integer:
+		return strconv.FormatInt(o.IntValue(), 10)
 	case types.Null:
 		return "<nil>"
+	case types.Bytes:
+		return "0x" + hex.EncodeToString(o.BytesValue())
+	case types.Hash160:
+		h := o.Hash160Value()
+		return "0x" + hex.EncodeToString(h[:])
+	case types.Hash256:
+		h := o.Hash256Value()
+		return "0x" + hex.EncodeToString(h[:])
+	case types.PublicKey:
+		return "0x" + hex.EncodeToString(o.PublicKeyValue())
+	case types.Signature:
+		return base64.StdEncoding.EncodeToString(o.SignatureValue())
 	default:
 		// See if it's a func; if yes, do function formatting.
 		if _, isfnc := o.t.(*symbols.FunctionType); isfnc {
@@ -123,6 +353,39 @@ func (o *Object) String() string {
 			return o.PointerValue().String()
 		}
 
+		// See if it's an array; if yes, format each of its elements.
+		if _, isarr := o.t.(*symbols.ArrayType); isarr {
+			var e string
+			for i, ptr := range o.ArrayValue() {
+				if i > 0 {
+					e += ","
+				}
+				e += ptr.String()
+			}
+			return "[" + e + "]"
+		}
+
+		// See if it's a map; if yes, format its entries in a deterministic (sorted) key order.
+		if _, ismap := o.t.(*symbols.MapType); ismap {
+			entries := o.MapValue()
+			keys := make([]string, 0, len(entries))
+			byKey := make(map[string]*Pointer)
+			for k, ptr := range entries {
+				ks := fmt.Sprintf("%v", k)
+				keys = append(keys, ks)
+				byKey[ks] = ptr
+			}
+			sort.Strings(keys)
+			var e string
+			for i, k := range keys {
+				if i > 0 {
+					e += ","
+				}
+				e += k + ":" + byKey[k].String()
+			}
+			return "map{" + e + "}"
+		}
+
 		// Otherwise it's an arbitrary object; just dump out the type and properties.
 		var p string
 		for prop, ptr := range o.properties {
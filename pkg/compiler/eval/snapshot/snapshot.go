@@ -0,0 +1,277 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+// Package snapshot supports externalizing the MuIL interpreter's object graph to a canonical JSON form, and
+// reconstructing it again later.  This is useful for debugging -- diffing interpreter state between two runs -- and
+// for checkpointing long-running evaluations so that they can be resumed after a crash or restart.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/marapongo/mu/pkg/compiler/eval"
+	"github.com/marapongo/mu/pkg/compiler/symbols"
+	"github.com/marapongo/mu/pkg/tokens"
+)
+
+// id is a stable identifier assigned to an object the first time it is encountered during a walk.  Objects that are
+// reachable more than once (i.e., that participate in sharing or cycles) are assigned a single id and are emitted
+// only once; every other reference to them serializes as an id reference rather than being inlined.
+type id int
+
+// snapshot is the canonical, JSON-serializable form of an interpreter heap rooted at a single object.
+type snapshot struct {
+	Root    id                `json:"root"`
+	Objects map[id]*objectRec `json:"objects"`
+}
+
+// objectRec is the serialized form of a single *eval.Object.  At most one of Primitive, Array, Map, Pointer, or
+// Function is set; Properties may accompany any of them, mirroring the fact that an eval.Object of any kind may
+// carry a property map.  Array, Map, and Pointer are structural wrapper types -- not entries in the symbol table -- so
+// Load reconstructs them directly from the record's shape rather than looking Type up.  A Map record additionally
+// carries KeyType and ValType, the tokens of its declared key and value element types, so that Load can resolve a
+// proper symbols.MapType via ctx.LookupType instead of an unusable MapType{nil, nil}.
+type objectRec struct {
+	Type       string        `json:"type"`
+	Primitive  interface{}   `json:"primitive,omitempty"`
+	Properties map[string]id `json:"properties,omitempty"`
+	Array      *[]id         `json:"array,omitempty"`
+	Map        []mapEntryRec `json:"map,omitempty"`
+	KeyType    string        `json:"keyType,omitempty"`
+	ValType    string        `json:"valType,omitempty"`
+	Pointer    *id           `json:"pointer,omitempty"`
+	Function   *functionRec  `json:"function,omitempty"`
+}
+
+// mapEntryRec is the serialized form of a single Map entry.  Key is the entry's raw, JSON-representable key value
+// (as stored directly in eval.Entries); Value is an id reference to the entry's target object.
+type mapEntryRec struct {
+	Key   interface{} `json:"key"`
+	Value id          `json:"value"`
+}
+
+// functionRec is the serialized form of a function object; it records just enough to re-resolve the function
+// against a live symbol table on Load, rather than attempting to clone its closure.
+type functionRec struct {
+	Token string `json:"token"`
+	This  *id    `json:"this,omitempty"`
+}
+
+// Save walks the object graph rooted at root, assigns stable ids to break cycles, and emits the canonical JSON form
+// to w.  An object encountered more than once while walking the graph always receives the same id, and a Pointer's
+// target always serializes as an id reference rather than being inlined, so the output faithfully preserves sharing.
+func Save(w io.Writer, root *eval.Object) error {
+	s := &saver{ids: make(map[*eval.Object]id), objects: make(map[id]*objectRec)}
+	snap := &snapshot{Root: s.walk(root), Objects: s.objects}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snap)
+}
+
+type saver struct {
+	ids     map[*eval.Object]id
+	objects map[id]*objectRec
+	next    id
+}
+
+// walk assigns (or reuses) an id for o, recording its serialized form the first time it is seen.
+func (s *saver) walk(o *eval.Object) id {
+	if o == nil {
+		return -1
+	}
+	if existing, has := s.ids[o]; has {
+		return existing
+	}
+
+	this := s.next
+	s.next++
+	s.ids[o] = this
+	// Reserve the slot before recursing, in case o is reachable from one of its own properties (a cycle).
+	s.objects[this] = nil
+
+	rec := &objectRec{Type: o.Type().Token().String()}
+	switch o.Type().(type) {
+	case *symbols.ArrayType:
+		arr := make([]id, 0, len(o.ArrayValue()))
+		for _, elem := range o.ArrayValue() {
+			arr = append(arr, s.walkPointer(elem))
+		}
+		rec.Array = &arr
+	case *symbols.MapType:
+		mapType := o.Type().(*symbols.MapType)
+		rec.KeyType = mapType.Key.Token().String()
+		rec.ValType = mapType.Value.Token().String()
+		// Walk entries in a deterministic (sorted) key order, so that ids are assigned the same way on every Save.
+		entries := o.MapValue()
+		keys := make([]interface{}, 0, len(entries))
+		for k := range entries {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(a, b int) bool { return fmt.Sprint(keys[a]) < fmt.Sprint(keys[b]) })
+		for _, k := range keys {
+			rec.Map = append(rec.Map, mapEntryRec{Key: k, Value: s.walkPointer(entries[k])})
+		}
+	case *symbols.PointerType:
+		target := s.walkPointer(o.PointerValue())
+		rec.Pointer = &target
+	case *symbols.FunctionType:
+		stub := o.FunctionValue()
+		frec := &functionRec{Token: stub.Func.Token().String()}
+		if stub.This != nil {
+			thisID := s.walk(stub.This)
+			frec.This = &thisID
+		}
+		rec.Function = frec
+	default:
+		if v := o.Value(); v != nil {
+			rec.Primitive = v
+		}
+	}
+
+	if props := o.Properties(); len(props) > 0 {
+		if rec.Properties == nil {
+			rec.Properties = make(map[string]id)
+		}
+		names := make([]tokens.Name, 0, len(props))
+		for nm := range props {
+			names = append(names, nm)
+		}
+		sort.Slice(names, func(a, b int) bool { return names[a].String() < names[b].String() })
+		for _, nm := range names {
+			rec.Properties[nm.String()] = s.walkPointer(props[nm])
+		}
+	}
+
+	s.objects[this] = rec
+	return this
+}
+
+// walkPointer dereferences a Pointer and walks its target, returning an id reference to it.
+func (s *saver) walkPointer(ptr *eval.Pointer) id {
+	if ptr == nil {
+		return -1
+	}
+	return s.walk(ptr.Obj)
+}
+
+// Load reconstructs the object graph previously written by Save, resolving functions against ctx's live symbol
+// table rather than cloning them.  Two ids that refer to the same object in the snapshot always deserialize to the
+// same *eval.Object pointer, preserving whatever sharing Save observed.
+func Load(r io.Reader, ctx *eval.Context) (*eval.Object, error) {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, err
+	}
+
+	l := &loader{snap: &snap, ctx: ctx, objects: make(map[id]*eval.Object)}
+	return l.load(snap.Root)
+}
+
+type loader struct {
+	snap    *snapshot
+	ctx     *eval.Context
+	objects map[id]*eval.Object
+}
+
+func (l *loader) load(i id) (*eval.Object, error) {
+	if i < 0 {
+		return nil, nil
+	}
+	if existing, has := l.objects[i]; has {
+		return existing, nil
+	}
+
+	rec, has := l.snap.Objects[i]
+	if !has {
+		return nil, fmt.Errorf("snapshot is missing a record for object id %v", i)
+	}
+
+	var obj *eval.Object
+	switch {
+	case rec.Function != nil:
+		fnc, ok := l.ctx.LookupFunction(tokens.Token(rec.Function.Token))
+		if !ok {
+			return nil, fmt.Errorf("unknown function token %q while loading snapshot", rec.Function.Token)
+		}
+		var this *eval.Object
+		if rec.Function.This != nil {
+			// Reserve the id first, in case the function's `this` refers back to an ancestor object.
+			l.objects[i] = nil
+			var err error
+			if this, err = l.load(*rec.Function.This); err != nil {
+				return nil, err
+			}
+		}
+		obj = eval.NewFunctionObject(fnc, this)
+		l.objects[i] = obj
+	case rec.Array != nil:
+		// Array is a structural type constructed directly from the record's shape; it is never looked up by token.
+		obj = eval.NewArrayObject(nil, make([]*eval.Pointer, len(*rec.Array)))
+		l.objects[i] = obj
+		arr := obj.ArrayValue()
+		for idx, eid := range *rec.Array {
+			elem, err := l.load(eid)
+			if err != nil {
+				return nil, err
+			}
+			arr[idx] = &eval.Pointer{Obj: elem}
+		}
+	case rec.Map != nil || rec.KeyType != "":
+		// Map, like Array, is a structural type; only its key/value element types -- not MapType itself -- are
+		// looked up by token.
+		keyType, ok := l.ctx.LookupType(tokens.Type(rec.KeyType))
+		if !ok {
+			return nil, fmt.Errorf("unknown map key type token %q while loading snapshot", rec.KeyType)
+		}
+		valType, ok := l.ctx.LookupType(tokens.Type(rec.ValType))
+		if !ok {
+			return nil, fmt.Errorf("unknown map value type token %q while loading snapshot", rec.ValType)
+		}
+		obj = eval.NewMapObject(keyType, valType)
+		l.objects[i] = obj
+		entries := obj.MapValue()
+		for _, e := range rec.Map {
+			target, err := l.load(e.Value)
+			if err != nil {
+				return nil, err
+			}
+			entries[e.Key] = &eval.Pointer{Obj: target}
+		}
+	case rec.Pointer != nil:
+		// Pointer is also structural; its target carries its own type information once loaded.  The pointer object is
+		// registered before its target is loaded, so that a cycle leading back to it resolves to this same object.
+		ptr := &eval.Pointer{}
+		obj = eval.NewPointerObject(ptr)
+		l.objects[i] = obj
+		target, err := l.load(*rec.Pointer)
+		if err != nil {
+			return nil, err
+		}
+		ptr.Obj = target
+	default:
+		sym, ok := l.ctx.LookupType(tokens.Type(rec.Type))
+		if !ok {
+			return nil, fmt.Errorf("unknown type token %q while loading snapshot", rec.Type)
+		}
+		obj = eval.NewObject(sym, rec.Primitive, make(eval.Properties))
+		l.objects[i] = obj
+	}
+
+	if err := l.loadProperties(obj, rec); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (l *loader) loadProperties(obj *eval.Object, rec *objectRec) error {
+	for nm, pid := range rec.Properties {
+		target, err := l.load(pid)
+		if err != nil {
+			return err
+		}
+		*obj.GetPropertyAddr(tokens.Name(nm), true) = eval.Pointer{Obj: target}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2016 Marapongo, Inc. All rights reserved.
+
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/marapongo/mu/pkg/compiler/eval"
+	"github.com/marapongo/mu/pkg/compiler/types"
+)
+
+func TestRoundTripPrimitive(t *testing.T) {
+	ctx := eval.NewContext()
+	ctx.RegisterType(types.String)
+
+	root := eval.NewObject(types.String, "hello", nil)
+	var buf bytes.Buffer
+	if err := Save(&buf, root); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(&buf, ctx)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := loaded.StringValue(); got != "hello" {
+		t.Fatalf("expected %q; got %q", "hello", got)
+	}
+}
+
+func TestRoundTripSharedReferencesPreserveIdentity(t *testing.T) {
+	ctx := eval.NewContext()
+	ctx.RegisterType(types.Number)
+
+	shared := eval.NewObject(types.Number, float64(42), nil)
+	arr := eval.NewArrayObject(types.Number, []*eval.Pointer{{Obj: shared}, {Obj: shared}})
+
+	var buf bytes.Buffer
+	if err := Save(&buf, arr); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(&buf, ctx)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	elems := loaded.ArrayValue()
+	if len(elems) != 2 {
+		t.Fatalf("expected 2 elements; got %v", len(elems))
+	}
+	if elems[0].Obj != elems[1].Obj {
+		t.Fatalf("expected two ids referring to the same object to deserialize to the same *Object pointer")
+	}
+}
+
+func TestRoundTripMap(t *testing.T) {
+	ctx := eval.NewContext()
+	ctx.RegisterType(types.String)
+
+	m := eval.NewMapObject(types.String, types.String)
+	key := eval.NewObject(types.String, "k", nil)
+	*m.GetEntryAddr(key, true) = eval.Pointer{Obj: eval.NewObject(types.String, "v", nil)}
+
+	var buf bytes.Buffer
+	if err := Save(&buf, m); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(&buf, ctx)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	entries := loaded.MapValue()
+	ref, has := entries["k"]
+	if !has {
+		t.Fatalf("expected entry for key %q", "k")
+	}
+	if got := ref.Obj.StringValue(); got != "v" {
+		t.Fatalf("expected value %q; got %q", "v", got)
+	}
+
+	// A reloaded map must retain its declared key/value types, so that ordinary accessors -- not just direct
+	// MapValue inspection -- keep working against it (e.g. after resuming from a checkpoint).
+	lookupKey := eval.NewObject(types.String, "k", nil)
+	if got := loaded.GetEntryAddr(lookupKey, false).Obj.StringValue(); got != "v" {
+		t.Fatalf("expected GetEntryAddr to find value %q; got %q", "v", got)
+	}
+	if got := loaded.GetIndexAddr(lookupKey, false).Obj.StringValue(); got != "v" {
+		t.Fatalf("expected GetIndexAddr to find value %q; got %q", "v", got)
+	}
+}
+
+func TestRoundTripPointer(t *testing.T) {
+	ctx := eval.NewContext()
+	ctx.RegisterType(types.Number)
+
+	target := eval.NewObject(types.Number, float64(7), nil)
+	ptrObj := eval.NewPointerObject(&eval.Pointer{Obj: target})
+
+	var buf bytes.Buffer
+	if err := Save(&buf, ptrObj); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(&buf, ctx)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := loaded.PointerValue().Obj.NumberValue(); got != 7 {
+		t.Fatalf("expected pointer target value 7; got %v", got)
+	}
+}